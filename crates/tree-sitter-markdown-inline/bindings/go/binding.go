@@ -0,0 +1,15 @@
+package tree_sitter_markdown_inline
+
+// #cgo CFLAGS: -std=c11 -fPIC
+// #include "../../src/tree_sitter/parser.h"
+// TSLanguage *tree_sitter_markdown_inline(void);
+import "C"
+
+import "unsafe"
+
+// Language returns the tree-sitter Language for the markdown inline
+// grammar: emphasis, links, code spans, and the other constructs that
+// only make sense inside the text collected by the block grammar.
+func Language() unsafe.Pointer {
+	return unsafe.Pointer(C.tree_sitter_markdown_inline())
+}
@@ -0,0 +1,15 @@
+package tree_sitter_markdown_inline_test
+
+import (
+	"testing"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_markdown_inline "github.com/tree-sitter/tree-sitter-markdown-inline/bindings/go"
+)
+
+func TestCanLoadGrammar(t *testing.T) {
+	language := tree_sitter.NewLanguage(tree_sitter_markdown_inline.Language())
+	if language == nil {
+		t.Errorf("Error loading Markdown inline grammar")
+	}
+}
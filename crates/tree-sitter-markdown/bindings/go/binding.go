@@ -0,0 +1,15 @@
+package tree_sitter_markdown
+
+// #cgo CFLAGS: -std=c11 -fPIC
+// #include "../../src/tree_sitter/parser.h"
+// TSLanguage *tree_sitter_markdown(void);
+import "C"
+
+import "unsafe"
+
+// Language returns the tree-sitter Language for the markdown block
+// grammar (the "split" grammar: block structure only, with inline text
+// left for the companion tree-sitter-markdown-inline grammar).
+func Language() unsafe.Pointer {
+	return unsafe.Pointer(C.tree_sitter_markdown())
+}
@@ -0,0 +1,15 @@
+package tree_sitter_markdown_test
+
+import (
+	"testing"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_markdown "github.com/tree-sitter/tree-sitter-markdown/bindings/go"
+)
+
+func TestCanLoadGrammar(t *testing.T) {
+	language := tree_sitter.NewLanguage(tree_sitter_markdown.Language())
+	if language == nil {
+		t.Errorf("Error loading Markdown grammar")
+	}
+}
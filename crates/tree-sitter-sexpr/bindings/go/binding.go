@@ -0,0 +1,15 @@
+package tree_sitter_sexpr
+
+// #cgo CFLAGS: -std=c11 -fPIC
+// #include "../../src/tree_sitter/parser.h"
+// TSLanguage *tree_sitter_sexpr(void);
+import "C"
+
+import "unsafe"
+
+// Language returns the tree-sitter Language for the sexpr grammar: a
+// minimal S-expression syntax of parenthesized lists and atoms, used to
+// parse the bodies of Quarto chunks.
+func Language() unsafe.Pointer {
+	return unsafe.Pointer(C.tree_sitter_sexpr())
+}
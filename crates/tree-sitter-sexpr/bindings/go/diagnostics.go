@@ -0,0 +1,146 @@
+package tree_sitter_sexpr
+
+import (
+	"fmt"
+	"strings"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// Severity classifies a ParseDiagnostic.
+type Severity int
+
+const (
+	// SeverityError marks a node tree-sitter could not parse at all
+	// (an ERROR node).
+	SeverityError Severity = iota
+	// SeverityMissing marks a node tree-sitter synthesized to recover
+	// from a missing required token (a MISSING node).
+	SeverityMissing
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityMissing:
+		return "missing"
+	default:
+		return "unknown"
+	}
+}
+
+// DiagnosticRange is the source span a ParseDiagnostic applies to.
+type DiagnosticRange struct {
+	StartPoint Point
+	EndPoint   Point
+	StartByte  uint
+	EndByte    uint
+}
+
+// ParseDiagnostic describes one syntax problem found in a parsed tree.
+type ParseDiagnostic struct {
+	Severity Severity
+	Message  string
+	Range    DiagnosticRange
+	// Expected lists the grammar symbols that would have been valid at
+	// Range.StartPoint, in the order tree-sitter's lookahead iterator
+	// produced them. It is empty when the parser has no lookahead state
+	// to consult (e.g. at the very start of the document).
+	Expected []string
+}
+
+// Diagnostics walks tree for ERROR and MISSING nodes and reports one
+// ParseDiagnostic per node found, in document order.
+func Diagnostics(tree *Tree, source []byte) []ParseDiagnostic {
+	var diags []ParseDiagnostic
+	lang := tree.Language()
+	walkDiagnostics(tree.RootNode(), lang, source, &diags)
+	return diags
+}
+
+func walkDiagnostics(n *Node, lang *tree_sitter.Language, source []byte, diags *[]ParseDiagnostic) {
+	switch {
+	case n.IsMissing():
+		*diags = append(*diags, ParseDiagnostic{
+			Severity: SeverityMissing,
+			Message:  fmt.Sprintf("missing %s", n.Kind()),
+			Range:    nodeRange(n),
+			Expected: expectedAt(n, lang),
+		})
+		return
+	case n.IsError():
+		*diags = append(*diags, ParseDiagnostic{
+			Severity: SeverityError,
+			Message:  "unexpected syntax",
+			Range:    nodeRange(n),
+			Expected: expectedAt(n, lang),
+		})
+	}
+
+	count := n.ChildCount()
+	for i := uint(0); i < count; i++ {
+		if child := n.Child(i); child != nil {
+			walkDiagnostics(child, lang, source, diags)
+		}
+	}
+}
+
+func nodeRange(n *Node) DiagnosticRange {
+	return DiagnosticRange{
+		StartPoint: n.StartPosition(),
+		EndPoint:   n.EndPosition(),
+		StartByte:  n.StartByte(),
+		EndByte:    n.EndByte(),
+	}
+}
+
+// expectedAt returns the grammar symbols valid at n's parse state, by
+// walking the language's lookahead iterator. It returns nil if n carries
+// no parse state (e.g. synthesized nodes without lookahead info).
+func expectedAt(n *Node, lang *tree_sitter.Language) []string {
+	state := n.ParseState()
+	iter := lang.LookaheadIterator(state)
+	if iter == nil {
+		return nil
+	}
+	defer iter.Close()
+
+	var expected []string
+	for _, name := range iter.IterNames() {
+		if name == "" || name == "ERROR" {
+			continue
+		}
+		expected = append(expected, name)
+	}
+	return expected
+}
+
+// FormatDiagnostics renders diags against src as rustc-style messages:
+// one line naming the problem, followed by the offending source line
+// with a caret under the span's start column.
+func FormatDiagnostics(src []byte, diags []ParseDiagnostic) string {
+	lines := strings.Split(string(src), "\n")
+
+	var b strings.Builder
+	for i, d := range diags {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		row := int(d.Range.StartPoint.Row)
+		col := int(d.Range.StartPoint.Column)
+
+		fmt.Fprintf(&b, "%s: %s at %d:%d", d.Severity, d.Message, row+1, col+1)
+		if len(d.Expected) > 0 {
+			fmt.Fprintf(&b, " (expected %s)", strings.Join(d.Expected, ", "))
+		}
+		b.WriteByte('\n')
+
+		if row >= 0 && row < len(lines) {
+			line := lines[row]
+			fmt.Fprintf(&b, "  %s\n", line)
+			fmt.Fprintf(&b, "  %s^\n", strings.Repeat(" ", col))
+		}
+	}
+	return b.String()
+}
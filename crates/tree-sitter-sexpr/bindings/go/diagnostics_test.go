@@ -0,0 +1,34 @@
+package tree_sitter_sexpr_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tree_sitter_sexpr "github.com/tree-sitter/tree-sitter-sexpr/bindings/go"
+)
+
+func TestDiagnosticsReportsUnclosedList(t *testing.T) {
+	p, err := tree_sitter_sexpr.NewParser()
+	if err != nil {
+		t.Fatalf("Error building Parser: %v", err)
+	}
+	defer p.Close()
+
+	source := []byte("(+ 1 2")
+	tree, err := p.Parse(context.Background(), nil, source)
+	if err != nil {
+		t.Fatalf("Error parsing source: %v", err)
+	}
+	defer tree.Close()
+
+	diags := tree_sitter_sexpr.Diagnostics(tree, source)
+	if len(diags) == 0 {
+		t.Fatalf("expected at least one diagnostic for unclosed list")
+	}
+
+	out := tree_sitter_sexpr.FormatDiagnostics(source, diags)
+	if !strings.Contains(out, "(+ 1 2") {
+		t.Errorf("FormatDiagnostics output missing source line context:\n%s", out)
+	}
+}
@@ -0,0 +1,98 @@
+package tree_sitter_sexpr
+
+import (
+	"context"
+	"fmt"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// Point is a row/column position within a source document.
+type Point = tree_sitter.Point
+
+// InputEdit describes a single edit to a source document, in the same
+// terms tree-sitter itself expects: the byte range that changed plus the
+// row/column of each endpoint, so the parser can reuse unchanged subtrees
+// instead of reparsing from scratch.
+type InputEdit struct {
+	StartByte  uint
+	OldEndByte uint
+	NewEndByte uint
+
+	StartPoint  Point
+	OldEndPoint Point
+	NewEndPoint Point
+}
+
+// Range identifies a byte/point span of a document that a Parser should
+// restrict parsing to, via ParseRanges.
+type Range = tree_sitter.Range
+
+// Tree is a parsed sexpr syntax tree.
+type Tree = tree_sitter.Tree
+
+// Node is a node within a Tree.
+type Node = tree_sitter.Node
+
+// Parser wraps a tree-sitter parser configured for the sexpr grammar,
+// adding an incremental, context-aware API on top of it. A Parser is not
+// safe for concurrent use.
+type Parser struct {
+	inner *tree_sitter.Parser
+}
+
+// NewParser builds a Parser with the sexpr grammar loaded.
+func NewParser() (*Parser, error) {
+	inner := tree_sitter.NewParser()
+	if err := inner.SetLanguage(tree_sitter.NewLanguage(Language())); err != nil {
+		return nil, fmt.Errorf("tree_sitter_sexpr: loading grammar: %w", err)
+	}
+	return &Parser{inner: inner}, nil
+}
+
+// Close releases the resources held by the underlying tree-sitter parser.
+func (p *Parser) Close() {
+	p.inner.Close()
+}
+
+// Parse parses source, reusing the unchanged parts of old if it is
+// non-nil and has been updated with Edit to describe how source diverges
+// from the tree it was built from. If ctx is cancelled before parsing
+// completes, the underlying parser halts cooperatively (via its
+// cancellation flag) and Parse returns ctx.Err().
+func (p *Parser) Parse(ctx context.Context, old *Tree, source []byte) (*Tree, error) {
+	tree := p.inner.ParseCtx(ctx, source, old)
+	if tree == nil {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("tree_sitter_sexpr: parse failed")
+	}
+	return tree, nil
+}
+
+// Edit records a source edit on tree so that a subsequent Parse call can
+// reuse the subtrees unaffected by the edit. Call this once for every
+// edit, in the order the edits were applied to the source.
+func (p *Parser) Edit(tree *Tree, edit InputEdit) {
+	tree.Edit(&tree_sitter.InputEdit{
+		StartByte:      edit.StartByte,
+		OldEndByte:     edit.OldEndByte,
+		NewEndByte:     edit.NewEndByte,
+		StartPosition:  edit.StartPoint,
+		OldEndPosition: edit.OldEndPoint,
+		NewEndPosition: edit.NewEndPoint,
+	})
+}
+
+// ParseRanges restricts subsequent Parse calls to the given byte/point
+// ranges of the document, rather than scanning it in full. This is
+// useful for callers, such as the Quarto IDE tooling, that only need to
+// reparse the sexpr regions embedded inside a larger markdown document.
+// Passing a nil or empty slice clears any previously set ranges.
+func (p *Parser) ParseRanges(ranges []Range) error {
+	if len(ranges) == 0 {
+		return p.inner.SetIncludedRanges(nil)
+	}
+	return p.inner.SetIncludedRanges(ranges)
+}
@@ -0,0 +1,112 @@
+package tree_sitter_sexpr_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	tree_sitter_sexpr "github.com/tree-sitter/tree-sitter-sexpr/bindings/go"
+)
+
+func TestParseAndEdit(t *testing.T) {
+	p, err := tree_sitter_sexpr.NewParser()
+	if err != nil {
+		t.Fatalf("Error building Parser: %v", err)
+	}
+	defer p.Close()
+
+	source := []byte("(add 1 2)")
+	tree, err := p.Parse(context.Background(), nil, source)
+	if err != nil {
+		t.Fatalf("Error parsing source: %v", err)
+	}
+	defer tree.Close()
+
+	edited := []byte("(add 10 2)")
+	p.Edit(tree, tree_sitter_sexpr.InputEdit{
+		StartByte:   5,
+		OldEndByte:  6,
+		NewEndByte:  7,
+		StartPoint:  tree_sitter_sexpr.Point{Row: 0, Column: 5},
+		OldEndPoint: tree_sitter_sexpr.Point{Row: 0, Column: 6},
+		NewEndPoint: tree_sitter_sexpr.Point{Row: 0, Column: 7},
+	})
+
+	newTree, err := p.Parse(context.Background(), tree, edited)
+	if err != nil {
+		t.Fatalf("Error reparsing edited source: %v", err)
+	}
+	defer newTree.Close()
+
+	if newTree.RootNode().HasError() {
+		t.Errorf("expected edited source to parse without error")
+	}
+}
+
+func TestParseRanges(t *testing.T) {
+	p, err := tree_sitter_sexpr.NewParser()
+	if err != nil {
+		t.Fatalf("Error building Parser: %v", err)
+	}
+	defer p.Close()
+
+	source := []byte("prose (add 1 2) more prose")
+	if err := p.ParseRanges([]tree_sitter_sexpr.Range{
+		{StartByte: 6, EndByte: 16},
+	}); err != nil {
+		t.Fatalf("Error setting parse ranges: %v", err)
+	}
+
+	tree, err := p.Parse(context.Background(), nil, source)
+	if err != nil {
+		t.Fatalf("Error parsing source: %v", err)
+	}
+	defer tree.Close()
+
+	root := tree.RootNode()
+	if root.StartByte() != 6 || root.EndByte() != 16 {
+		t.Errorf("expected root range [6, 16), got [%d, %d)", root.StartByte(), root.EndByte())
+	}
+}
+
+func BenchmarkReparseAfterSmallEdit(b *testing.B) {
+	p, err := tree_sitter_sexpr.NewParser()
+	if err != nil {
+		b.Fatalf("Error building Parser: %v", err)
+	}
+	defer p.Close()
+
+	var sb strings.Builder
+	sb.WriteString("(")
+	for i := 0; i < 50000; i++ {
+		fmt.Fprintf(&sb, "(atom%d) ", i)
+	}
+	sb.WriteString(")")
+	source := []byte(sb.String())
+
+	tree, err := p.Parse(context.Background(), nil, source)
+	if err != nil {
+		b.Fatalf("Error parsing source: %v", err)
+	}
+	defer tree.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Edit(tree, tree_sitter_sexpr.InputEdit{
+			StartByte:   1,
+			OldEndByte:  1,
+			NewEndByte:  2,
+			StartPoint:  tree_sitter_sexpr.Point{Row: 0, Column: 1},
+			OldEndPoint: tree_sitter_sexpr.Point{Row: 0, Column: 1},
+			NewEndPoint: tree_sitter_sexpr.Point{Row: 0, Column: 2},
+		})
+		edited := append([]byte("x"), source...)
+
+		next, err := p.Parse(context.Background(), tree, edited)
+		if err != nil {
+			b.Fatalf("Error reparsing: %v", err)
+		}
+		next.Close()
+	}
+}
@@ -0,0 +1,82 @@
+package tree_sitter_sexpr
+
+import (
+	"embed"
+	"fmt"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+//go:embed queries/*.scm
+var queryFiles embed.FS
+
+// Query is a compiled sexpr query, built from an S-expression pattern
+// string via NewQuery or LoadQuery.
+type Query struct {
+	inner *tree_sitter.Query
+}
+
+// NewQuery compiles source as a sexpr query.
+func NewQuery(source string) (*Query, error) {
+	q, queryErr := tree_sitter.NewQuery(tree_sitter.NewLanguage(Language()), source)
+	if queryErr != nil {
+		return nil, fmt.Errorf("tree_sitter_sexpr: compiling query: %s at %d:%d", queryErr.Message, queryErr.Row, queryErr.Column)
+	}
+	return &Query{inner: q}, nil
+}
+
+// LoadQuery loads one of the query files embedded from queries/, e.g.
+// "highlights", "locals", or "tags".
+func LoadQuery(name string) (*Query, error) {
+	src, err := queryFiles.ReadFile("queries/" + name + ".scm")
+	if err != nil {
+		return nil, fmt.Errorf("tree_sitter_sexpr: no query named %q: %w", name, err)
+	}
+	return NewQuery(string(src))
+}
+
+// Close releases the resources held by the underlying compiled query.
+func (q *Query) Close() {
+	q.inner.Close()
+}
+
+// Capture is a single named capture produced by a query match.
+type Capture struct {
+	Name  string
+	Node  Node
+	Bytes []byte
+}
+
+// Match is a single match of a Query against a tree, with its captures
+// resolved to names and bytes from the matched source.
+type Match struct {
+	PatternIndex uint32
+	Captures     []Capture
+}
+
+// Matches runs q against node, reading capture text from source. Matches
+// whose #eq?, #match?, or #any-of? predicates are not satisfied are
+// filtered out by go-tree-sitter itself before they reach here.
+func (q *Query) Matches(node *Node, source []byte) []Match {
+	cursor := tree_sitter.NewQueryCursor()
+	defer cursor.Close()
+
+	var matches []Match
+	qm := cursor.Matches(q.inner, node, source)
+	for {
+		m := qm.Next()
+		if m == nil {
+			break
+		}
+		match := Match{PatternIndex: uint32(m.PatternIndex)}
+		for _, c := range m.Captures {
+			match.Captures = append(match.Captures, Capture{
+				Name:  q.inner.CaptureNames()[c.Index],
+				Node:  c.Node,
+				Bytes: []byte(c.Node.Utf8Text(source)),
+			})
+		}
+		matches = append(matches, match)
+	}
+	return matches
+}
@@ -0,0 +1,61 @@
+package tree_sitter_sexpr_test
+
+import (
+	"context"
+	"testing"
+
+	tree_sitter_sexpr "github.com/tree-sitter/tree-sitter-sexpr/bindings/go"
+)
+
+func TestQueryCaptures(t *testing.T) {
+	p, err := tree_sitter_sexpr.NewParser()
+	if err != nil {
+		t.Fatalf("Error building Parser: %v", err)
+	}
+	defer p.Close()
+
+	source := []byte("(add 1 2 3)")
+	tree, err := p.Parse(context.Background(), nil, source)
+	if err != nil {
+		t.Fatalf("Error parsing source: %v", err)
+	}
+	defer tree.Close()
+
+	q, err := tree_sitter_sexpr.NewQuery(`(list . (atom) @head . (_)* @rest)`)
+	if err != nil {
+		t.Fatalf("Error compiling query: %v", err)
+	}
+	defer q.Close()
+
+	matches := q.Matches(tree.RootNode(), source)
+	if len(matches) != 1 {
+		t.Fatalf("expected 1 match, got %d", len(matches))
+	}
+
+	var head string
+	var rest int
+	for _, c := range matches[0].Captures {
+		switch c.Name {
+		case "head":
+			head = string(c.Bytes)
+		case "rest":
+			rest++
+		}
+	}
+	if head != "add" {
+		t.Errorf("head capture = %q, want %q", head, "add")
+	}
+	if rest != 3 {
+		t.Errorf("rest capture count = %d, want 3", rest)
+	}
+}
+
+func TestLoadQuery(t *testing.T) {
+	for _, name := range []string{"highlights", "locals", "tags"} {
+		q, err := tree_sitter_sexpr.LoadQuery(name)
+		if err != nil {
+			t.Fatalf("Error loading query %q: %v", name, err)
+		}
+		q.Close()
+	}
+}
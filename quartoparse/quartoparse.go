@@ -0,0 +1,264 @@
+// Package quartoparse composes the markdown block grammar, the markdown
+// inline grammar, and the sexpr grammar into a single syntax tree for a
+// Quarto (.qmd) document.
+//
+// A Quarto document is, at the top level, markdown: the block grammar
+// produces the paragraph/list/heading/fenced-code structure. Any node the
+// block grammar leaves as raw "inline" text is re-parsed with the inline
+// grammar, and any fenced code block whose info string names a Quarto
+// chunk (e.g. "```{r}") has its body parsed with the sexpr grammar. The
+// resulting Node tree lets callers walk prose and embedded chunk bodies
+// with the same API, without caring which grammar produced a given node.
+package quartoparse
+
+import (
+	"fmt"
+	"regexp"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_markdown_inline "github.com/tree-sitter/tree-sitter-markdown-inline/bindings/go"
+	tree_sitter_markdown "github.com/tree-sitter/tree-sitter-markdown/bindings/go"
+	tree_sitter_sexpr "github.com/tree-sitter/tree-sitter-sexpr/bindings/go"
+)
+
+// Source identifies which grammar produced a Node.
+type Source int
+
+const (
+	SourceMarkdownBlock Source = iota
+	SourceMarkdownInline
+	SourceSexpr
+)
+
+func (s Source) String() string {
+	switch s {
+	case SourceMarkdownBlock:
+		return "markdown"
+	case SourceMarkdownInline:
+		return "markdown-inline"
+	case SourceSexpr:
+		return "sexpr"
+	default:
+		return "unknown"
+	}
+}
+
+// Node is a single node in the composed document tree. Byte offsets and
+// points are always relative to the original document, even for nodes
+// produced by a sub-parse (inline text or a sexpr chunk body), so callers
+// can slice the original source directly.
+type Node struct {
+	Kind       string
+	Source     Source
+	StartByte  uint
+	EndByte    uint
+	StartPoint tree_sitter.Point
+	EndPoint   tree_sitter.Point
+	Children   []*Node
+}
+
+// leafIdentifier matches a fenced code block info string that names a
+// Quarto executable chunk, e.g. "{r}" or "{python}".
+var leafIdentifier = regexp.MustCompile(`^\{([a-zA-Z][\w.-]*)\}`)
+
+// Parser parses Quarto documents by composing the markdown, markdown
+// inline, and sexpr grammars. A Parser is not safe for concurrent use;
+// callers that need to parse documents concurrently should use one
+// Parser per goroutine.
+type Parser struct {
+	block  *tree_sitter.Parser
+	inline *tree_sitter.Parser
+	sexpr  *tree_sitter.Parser
+}
+
+// NewParser builds a Parser with the block, inline, and sexpr grammars
+// already loaded.
+func NewParser() (*Parser, error) {
+	p := &Parser{
+		block:  tree_sitter.NewParser(),
+		inline: tree_sitter.NewParser(),
+		sexpr:  tree_sitter.NewParser(),
+	}
+	if err := p.block.SetLanguage(tree_sitter.NewLanguage(tree_sitter_markdown.Language())); err != nil {
+		return nil, fmt.Errorf("quartoparse: loading markdown grammar: %w", err)
+	}
+	if err := p.inline.SetLanguage(tree_sitter.NewLanguage(tree_sitter_markdown_inline.Language())); err != nil {
+		return nil, fmt.Errorf("quartoparse: loading markdown inline grammar: %w", err)
+	}
+	if err := p.sexpr.SetLanguage(tree_sitter.NewLanguage(tree_sitter_sexpr.Language())); err != nil {
+		return nil, fmt.Errorf("quartoparse: loading sexpr grammar: %w", err)
+	}
+	return p, nil
+}
+
+// Close releases the resources held by the underlying grammar parsers.
+func (p *Parser) Close() {
+	p.block.Close()
+	p.inline.Close()
+	p.sexpr.Close()
+}
+
+// Parse parses source as a Quarto document and returns the root of the
+// composed tree.
+func (p *Parser) Parse(source []byte) (*Node, error) {
+	blockTree := p.block.Parse(source, nil)
+	if blockTree == nil {
+		return nil, fmt.Errorf("quartoparse: markdown block parse failed")
+	}
+	defer blockTree.Close()
+
+	return p.convert(blockTree.RootNode(), source), nil
+}
+
+// convert walks a tree-sitter node produced by the block grammar into a
+// composed Node, substituting inline and sexpr subtrees where applicable.
+func (p *Parser) convert(n *tree_sitter.Node, source []byte) *Node {
+	if n.Kind() == "inline" {
+		return p.convertInline(n, source)
+	}
+	if fenced := p.sexprChunk(n, source); fenced != nil {
+		return fenced
+	}
+
+	out := &Node{
+		Kind:       n.Kind(),
+		Source:     SourceMarkdownBlock,
+		StartByte:  n.StartByte(),
+		EndByte:    n.EndByte(),
+		StartPoint: n.StartPosition(),
+		EndPoint:   n.EndPosition(),
+	}
+	count := n.ChildCount()
+	out.Children = make([]*Node, 0, count)
+	for i := uint(0); i < count; i++ {
+		child := n.Child(i)
+		if child == nil {
+			continue
+		}
+		out.Children = append(out.Children, p.convert(child, source))
+	}
+	return out
+}
+
+// convertInline re-parses the byte range of an "inline" block-grammar node
+// with the inline grammar, translating the resulting subtree's offsets
+// back into the original document.
+func (p *Parser) convertInline(n *tree_sitter.Node, source []byte) *Node {
+	start, end := n.StartByte(), n.EndByte()
+	inlineTree := p.inline.Parse(source[start:end], nil)
+	if inlineTree == nil {
+		return &Node{
+			Kind:       n.Kind(),
+			Source:     SourceMarkdownBlock,
+			StartByte:  start,
+			EndByte:    end,
+			StartPoint: n.StartPosition(),
+			EndPoint:   n.EndPosition(),
+		}
+	}
+	defer inlineTree.Close()
+
+	return offsetInline(inlineTree.RootNode(), start, n.StartPosition())
+}
+
+// offsetInline converts an inline-grammar node into a composed Node whose
+// byte offsets and points are shifted from the inline sub-document back
+// into the coordinates of the original document.
+func offsetInline(n *tree_sitter.Node, byteOffset uint, pointOffset tree_sitter.Point) *Node {
+	out := &Node{
+		Kind:       n.Kind(),
+		Source:     SourceMarkdownInline,
+		StartByte:  n.StartByte() + byteOffset,
+		EndByte:    n.EndByte() + byteOffset,
+		StartPoint: addPoint(n.StartPosition(), pointOffset),
+		EndPoint:   addPoint(n.EndPosition(), pointOffset),
+	}
+	count := n.ChildCount()
+	out.Children = make([]*Node, 0, count)
+	for i := uint(0); i < count; i++ {
+		child := n.Child(i)
+		if child == nil {
+			continue
+		}
+		out.Children = append(out.Children, offsetInline(child, byteOffset, pointOffset))
+	}
+	return out
+}
+
+func addPoint(p, offset tree_sitter.Point) tree_sitter.Point {
+	if p.Row == 0 {
+		return tree_sitter.Point{Row: offset.Row, Column: p.Column + offset.Column}
+	}
+	return tree_sitter.Point{Row: offset.Row + p.Row, Column: p.Column}
+}
+
+// sexprChunk checks whether n is a fenced code block naming a Quarto
+// chunk (e.g. "```{r}"). If so it parses the chunk body with the sexpr
+// grammar and returns the composed node; otherwise it returns nil.
+func (p *Parser) sexprChunk(n *tree_sitter.Node, source []byte) *Node {
+	if n.Kind() != "fenced_code_block" {
+		return nil
+	}
+
+	var infoNode, bodyNode *tree_sitter.Node
+	count := n.ChildCount()
+	for i := uint(0); i < count; i++ {
+		child := n.Child(i)
+		if child == nil {
+			continue
+		}
+		switch child.Kind() {
+		case "info_string":
+			infoNode = child
+		case "code_fence_content":
+			bodyNode = child
+		}
+	}
+	if infoNode == nil || bodyNode == nil {
+		return nil
+	}
+	info := infoNode.Utf8Text(source)
+	if !leafIdentifier.MatchString(info) {
+		return nil
+	}
+
+	start, end := bodyNode.StartByte(), bodyNode.EndByte()
+	sexprTree := p.sexpr.Parse(source[start:end], nil)
+	if sexprTree == nil {
+		return nil
+	}
+	defer sexprTree.Close()
+
+	body := offsetSexpr(sexprTree.RootNode(), start, bodyNode.StartPosition())
+
+	return &Node{
+		Kind:       n.Kind(),
+		Source:     SourceMarkdownBlock,
+		StartByte:  n.StartByte(),
+		EndByte:    n.EndByte(),
+		StartPoint: n.StartPosition(),
+		EndPoint:   n.EndPosition(),
+		Children:   []*Node{body},
+	}
+}
+
+func offsetSexpr(n *tree_sitter.Node, byteOffset uint, pointOffset tree_sitter.Point) *Node {
+	out := &Node{
+		Kind:       n.Kind(),
+		Source:     SourceSexpr,
+		StartByte:  n.StartByte() + byteOffset,
+		EndByte:    n.EndByte() + byteOffset,
+		StartPoint: addPoint(n.StartPosition(), pointOffset),
+		EndPoint:   addPoint(n.EndPosition(), pointOffset),
+	}
+	count := n.ChildCount()
+	out.Children = make([]*Node, 0, count)
+	for i := uint(0); i < count; i++ {
+		child := n.Child(i)
+		if child == nil {
+			continue
+		}
+		out.Children = append(out.Children, offsetSexpr(child, byteOffset, pointOffset))
+	}
+	return out
+}
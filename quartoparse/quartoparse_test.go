@@ -0,0 +1,58 @@
+package quartoparse_test
+
+import (
+	"testing"
+
+	"github.com/quarto-dev/quarto-markdown/quartoparse"
+)
+
+func TestCanLoadParser(t *testing.T) {
+	p, err := quartoparse.NewParser()
+	if err != nil {
+		t.Fatalf("Error building quartoparse.Parser: %v", err)
+	}
+	defer p.Close()
+}
+
+// TestChunkByteRangeRoundTrips checks that the sexpr subtree attached for
+// a Quarto code chunk reports byte offsets into the *original* document,
+// not the extracted chunk body.
+func TestChunkByteRangeRoundTrips(t *testing.T) {
+	p, err := quartoparse.NewParser()
+	if err != nil {
+		t.Fatalf("Error building quartoparse.Parser: %v", err)
+	}
+	defer p.Close()
+
+	source := []byte("# Title\n\n```{r}\n(+ 1 2)\n```\n")
+	root, err := p.Parse(source)
+	if err != nil {
+		t.Fatalf("Error parsing document: %v", err)
+	}
+
+	var chunk *quartoparse.Node
+	var walk func(n *quartoparse.Node)
+	walk = func(n *quartoparse.Node) {
+		if n.Kind == "fenced_code_block" {
+			chunk = n
+		}
+		for _, c := range n.Children {
+			walk(c)
+		}
+	}
+	walk(root)
+
+	if chunk == nil {
+		t.Fatalf("expected a fenced_code_block node, found none")
+	}
+	if len(chunk.Children) != 1 || chunk.Children[0].Source != quartoparse.SourceSexpr {
+		t.Fatalf("expected fenced_code_block to have a sexpr child, got %+v", chunk.Children)
+	}
+
+	body := chunk.Children[0]
+	got := string(source[body.StartByte:body.EndByte])
+	want := "(+ 1 2)\n"
+	if got != want {
+		t.Errorf("sexpr chunk byte range = %q, want %q", got, want)
+	}
+}